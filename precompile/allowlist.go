@@ -0,0 +1,154 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	setAdminSignature      = CalculateFunctionSelector("setAdmin(address)")
+	setEnabledSignature    = CalculateFunctionSelector("setEnabled(address)")
+	setNoneSignature       = CalculateFunctionSelector("setNone(address)")
+	readAllowListSignature = CalculateFunctionSelector("readAllowList(address)")
+
+	// RoleSetEventSignature is the topic0 hash emitted via stateDB.AddLog on every
+	// setAdmin/setEnabled/setNone call (see createAllowListRoleSetter), carrying (caller,
+	// address, oldRole, newRole) so indexers can subscribe to allow-list membership changes
+	// across every allow-list precompile (ContractNativeMinter, AssetRegistry, FeeConfigManager,
+	// TxAllowList) without polling each one's storage. Roles written directly through
+	// setAllowListRole, including those AllowListConfig.Configure assigns, emit no log.
+	RoleSetEventSignature = crypto.Keccak256Hash([]byte("RoleSet(address,address,uint8,uint8)"))
+
+	ErrCannotModifyAllowList = errors.New("non-admin cannot modify allow list")
+
+	allowListInputLen = common.HashLength
+)
+
+// ModifyAllowListGasCost and ReadAllowListGasCost are the base costs for changing and reading
+// a single address's role in an allow list, modeled after a single storage slot read/write.
+const (
+	ModifyAllowListGasCost = writeGasCostPerSlot
+	ReadAllowListGasCost   = 5000
+)
+
+// AllowListRole encodes an address's permission level for an allow-list-gated precompile.
+type AllowListRole common.Hash
+
+var (
+	AllowListNoRole  AllowListRole
+	AllowListEnabled = AllowListRole(common.BigToHash(common.Big1))
+	AllowListAdmin   = AllowListRole(common.BigToHash(common.Big2))
+)
+
+// IsAdmin returns true if [d] indicates the permission to modify the allow list.
+func (d AllowListRole) IsAdmin() bool {
+	return d == AllowListAdmin
+}
+
+// IsEnabled returns true if [d] indicates the permission to use the precompile.
+func (d AllowListRole) IsEnabled() bool {
+	return d == AllowListEnabled || d.IsAdmin()
+}
+
+// byte returns the last byte of [d], the only byte a role's value ever occupies.
+func (d AllowListRole) byte() byte {
+	return d[common.HashLength-1]
+}
+
+// AllowListConfig specifies the initial set of admin and enabled addresses for an allow-list
+// precompile. Embedded by every allow-list-gated precompile's Config type.
+type AllowListConfig struct {
+	AdminAddresses   []common.Address `json:"adminAddresses,omitempty"`
+	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"`
+}
+
+// Configure sets the initial admin and enabled addresses for the allow list at
+// [precompileAddr].
+func (c *AllowListConfig) Configure(state StateDB, precompileAddr common.Address) {
+	for _, addr := range c.AdminAddresses {
+		setAllowListRole(state, precompileAddr, addr, AllowListAdmin)
+	}
+	for _, addr := range c.EnabledAddresses {
+		setAllowListRole(state, precompileAddr, addr, AllowListEnabled)
+	}
+}
+
+// allowListKey returns the storage key holding [address]'s role within the allow list at
+// [precompileAddr]'s own storage.
+func allowListKey(address common.Address) common.Hash {
+	return address.Hash()
+}
+
+// getAllowListStatus returns the role of [address] for the allow list at [precompileAddr].
+func getAllowListStatus(stateDB StateDB, precompileAddr common.Address, address common.Address) AllowListRole {
+	return AllowListRole(stateDB.GetState(precompileAddr, allowListKey(address)))
+}
+
+// setAllowListRole sets the permissions of [address] to [role] for the allow list at
+// [precompileAddr]. assumes [role] has already been verified as valid.
+func setAllowListRole(stateDB StateDB, precompileAddr common.Address, address common.Address, role AllowListRole) {
+	stateDB.SetState(precompileAddr, allowListKey(address), common.Hash(role))
+}
+
+// createAllowListRoleSetter returns the execution function backing setAdmin/setEnabled/setNone
+// for the allow list at [precompileAddr]: it requires the caller already hold AllowListAdmin,
+// assigns [role] to the address packed in the input, and emits a RoleSet log carrying the
+// address's prior and new role so indexers can observe the change without polling storage.
+func createAllowListRoleSetter(precompileAddr common.Address, role AllowListRole) func(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return func(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = deductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for modifying allow list: %d", len(input))
+		}
+		modifyAddress := common.BytesToAddress(returnPackedElement(input, 0))
+
+		stateDB := accessibleState.GetStateDB()
+		callerStatus := getAllowListStatus(stateDB, precompileAddr, caller)
+		if !callerStatus.IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotModifyAllowList, caller)
+		}
+
+		oldRole := getAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		setAllowListRole(stateDB, precompileAddr, modifyAddress, role)
+		stateDB.AddLog(
+			precompileAddr,
+			[]common.Hash{RoleSetEventSignature, caller.Hash(), modifyAddress.Hash()},
+			append(common.LeftPadBytes([]byte{oldRole.byte()}, 32), common.LeftPadBytes([]byte{role.byte()}, 32)...),
+			accessibleState.GetBlockContext().Number().Uint64(),
+		)
+
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createReadAllowList returns the execution function backing readAllowList for the allow list
+// at [precompileAddr]: it returns the role of the address packed in the input.
+func createReadAllowList(precompileAddr common.Address) func(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return func(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = deductGas(suppliedGas, ReadAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for reading allow list: %d", len(input))
+		}
+		readAddress := common.BytesToAddress(returnPackedElement(input, 0))
+
+		role := getAllowListStatus(accessibleState.GetStateDB(), precompileAddr, readAddress)
+		return common.Hash(role).Bytes(), remainingGas, nil
+	}
+}
@@ -0,0 +1,373 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	_ StatefulPrecompileConfig = &AssetRegistryConfig{}
+	// Singleton StatefulPrecompiledContract giving subnets multi-asset support without
+	// deploying an ERC-20 factory per asset.
+	AssetRegistryPrecompile StatefulPrecompiledContract = createAssetRegistryPrecompile(AssetRegistryAddress)
+
+	registerAssetSignature = CalculateFunctionSelector("registerAsset(string,string,uint8,uint256)")
+	mintAssetSignature     = CalculateFunctionSelector("mintAsset(uint256,address,uint256)")
+	burnAssetSignature     = CalculateFunctionSelector("burnAsset(uint256,address,uint256)")
+	getAssetSignature      = CalculateFunctionSelector("getAsset(uint256)")
+
+	ErrCannotRegisterAsset = errors.New("non-enabled cannot register asset")
+	ErrCannotMintAsset     = errors.New("non-enabled cannot mint asset")
+	ErrCannotBurnAsset     = errors.New("non-enabled cannot burn asset")
+	ErrAssetNotFound       = errors.New("asset not found")
+	ErrAssetCapExceeded    = errors.New("mint would exceed asset cap")
+	ErrInsufficientBalance = errors.New("insufficient asset balance")
+
+	// assetCounterSlot holds the most recently assigned assetID (zero before any registration).
+	assetCounterSlot = common.Hash{}
+)
+
+// registerAssetGasCost and getAssetGasCost are modeled after the fixed-field read/write costs
+// used elsewhere in this package; mint/burn reuse ContractNativeMinter's MintGasCost, although
+// each of them rewrites the asset's struct slots (see [setAsset]) as well as one balance slot.
+const (
+	registerAssetGasCost = MintGasCost * 2
+	getAssetGasCost      = ReadAllowListGasCost * 5
+)
+
+// AssetRegistryConfig wraps [AllowListConfig] and uses it to implement the
+// StatefulPrecompileConfig interface while adding the asset registry precompile address.
+type AssetRegistryConfig struct {
+	AllowListConfig
+}
+
+// Address returns the address of the asset registry contract.
+func (c *AssetRegistryConfig) Address() common.Address {
+	return AssetRegistryAddress
+}
+
+// Configure configures [state] with the desired admins based on [c].
+func (c *AssetRegistryConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, AssetRegistryAddress)
+}
+
+// Contract returns the singleton stateful precompiled contract to be used for the asset registry.
+func (c *AssetRegistryConfig) Contract() StatefulPrecompiledContract {
+	return AssetRegistryPrecompile
+}
+
+// GetAssetRegistryStatus returns the role of [address] for the asset registry allow list.
+func GetAssetRegistryStatus(stateDB StateDB, address common.Address) AllowListRole {
+	return getAllowListStatus(stateDB, AssetRegistryAddress, address)
+}
+
+// SetAssetRegistryStatus sets the permissions of [address] to [role] for the asset registry
+// allow list. assumes [role] has already been verified as valid.
+func SetAssetRegistryStatus(stateDB StateDB, address common.Address, role AllowListRole) {
+	setAllowListRole(stateDB, AssetRegistryAddress, address, role)
+}
+
+// assetBaseSlot returns the first of the five consecutive storage slots holding the struct
+// for [assetID]: name, symbol, decimals, cap, totalSupply (in that order), mirroring how
+// Solidity lays out a struct stored at keccak256(assetID).
+func assetBaseSlot(assetID *big.Int) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(assetID).Bytes()))
+}
+
+// assetBalanceKey returns the storage key for [holder]'s balance of [assetID].
+func assetBalanceKey(assetID *big.Int, holder common.Address) common.Hash {
+	return crypto.Keccak256Hash(common.BigToHash(assetID).Bytes(), holder.Bytes())
+}
+
+// Asset is the metadata and total supply tracked for a single registered asset.
+type Asset struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	Cap         *big.Int
+	TotalSupply *big.Int
+}
+
+// assetExists reports whether [assetID] has been registered. Assets are assigned sequentially
+// starting from 1 by [nextAssetID] and never deleted, so [assetID] exists iff it falls within
+// the range already handed out by the counter. A dedicated check is needed because the Cap
+// field cannot be used as an existence marker: createMintAsset treats Cap<=0 as "uncapped",
+// so a legitimately uncapped asset must still be readable.
+func assetExists(stateDB StateDB, assetID *big.Int) bool {
+	if assetID.Sign() <= 0 {
+		return false
+	}
+	counter := stateDB.GetState(AssetRegistryAddress, assetCounterSlot).Big()
+	return assetID.Cmp(counter) <= 0
+}
+
+// getAsset reads the [assetID] struct out of [stateDB]. Returns ErrAssetNotFound if [assetID]
+// has never been registered.
+func getAsset(stateDB StateDB, assetID *big.Int) (Asset, error) {
+	if !assetExists(stateDB, assetID) {
+		return Asset{}, fmt.Errorf("%w: %s", ErrAssetNotFound, assetID)
+	}
+
+	base := assetBaseSlot(assetID)
+	nameHash := stateDB.GetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(0))))
+	symbolHash := stateDB.GetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(1))))
+	decimalsHash := stateDB.GetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(2))))
+	capHash := stateDB.GetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(3))))
+	supplyHash := stateDB.GetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(4))))
+
+	return Asset{
+		Name:        string(trimTrailingZeroes(nameHash.Bytes())),
+		Symbol:      string(trimTrailingZeroes(symbolHash.Bytes())),
+		Decimals:    decimalsHash.Bytes()[common.HashLength-1],
+		Cap:         capHash.Big(),
+		TotalSupply: supplyHash.Big(),
+	}, nil
+}
+
+// trimTrailingZeroes strips the zero padding a left-aligned short string leaves at the end of
+// a single 32-byte slot (see [setAsset]).
+func trimTrailingZeroes(b []byte) []byte {
+	i := len(b)
+	for i > 0 && b[i-1] == 0 {
+		i--
+	}
+	return b[:i]
+}
+
+// leftAlignedHash packs [b] into the front of a 32-byte slot, zero-padded at the end, so it
+// round-trips through [trimTrailingZeroes]. [b] is truncated to 32 bytes if longer.
+func leftAlignedHash(b []byte) common.Hash {
+	var h common.Hash
+	copy(h[:], b)
+	return h
+}
+
+// setAsset writes the [assetID] struct into [stateDB]. [name] and [symbol] are truncated to 32
+// bytes, matching the fixed-word storage layout used throughout this package.
+func setAsset(stateDB StateDB, assetID *big.Int, asset Asset) {
+	base := assetBaseSlot(assetID)
+	stateDB.SetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(0))), leftAlignedHash([]byte(asset.Name)))
+	stateDB.SetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(1))), leftAlignedHash([]byte(asset.Symbol)))
+	stateDB.SetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(2))), common.BytesToHash([]byte{asset.Decimals}))
+	stateDB.SetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(3))), common.BigToHash(asset.Cap))
+	stateDB.SetState(AssetRegistryAddress, common.BigToHash(new(big.Int).Add(base, big.NewInt(4))), common.BigToHash(asset.TotalSupply))
+}
+
+// nextAssetID allocates and persists the next assetID, starting from 1 so that 0 remains
+// reserved to mean "no asset".
+func nextAssetID(stateDB StateDB) *big.Int {
+	counter := stateDB.GetState(AssetRegistryAddress, assetCounterSlot).Big()
+	assetID := new(big.Int).Add(counter, common.Big1)
+	stateDB.SetState(AssetRegistryAddress, assetCounterSlot, common.BigToHash(assetID))
+	return assetID
+}
+
+// createRegisterAsset registers a new asset and returns its freshly allocated assetID.
+func createRegisterAsset(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, registerAssetGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	if len(input) < common.HashLength*4 {
+		return nil, remainingGas, fmt.Errorf("invalid input length for registerAsset: %d", len(input))
+	}
+	nameOffset := new(big.Int).SetBytes(input[0:common.HashLength]).Uint64()
+	symbolOffset := new(big.Int).SetBytes(input[common.HashLength : 2*common.HashLength]).Uint64()
+	decimals := input[3*common.HashLength-1]
+	cap := new(big.Int).SetBytes(input[3*common.HashLength : 4*common.HashLength])
+
+	name, err := decodeABIBytes(input, nameOffset)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	symbol, err := decodeABIBytes(input, symbolOffset)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := getAllowListStatus(stateDB, AssetRegistryAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotRegisterAsset, caller)
+	}
+
+	assetID := nextAssetID(stateDB)
+	setAsset(stateDB, assetID, Asset{
+		Name:        string(name),
+		Symbol:      string(symbol),
+		Decimals:    decimals,
+		Cap:         cap,
+		TotalSupply: common.Big0,
+	})
+
+	return common.BigToHash(assetID).Bytes(), remainingGas, nil
+}
+
+// createMintAsset mints [amount] of [assetID] to [to], enforcing the asset's cap.
+func createMintAsset(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, MintGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	assetID, to, amount, err := unpackAssetAmountInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := getAllowListStatus(stateDB, AssetRegistryAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMintAsset, caller)
+	}
+
+	asset, err := getAsset(stateDB, assetID)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	newSupply := new(big.Int).Add(asset.TotalSupply, amount)
+	if asset.Cap.Sign() > 0 && newSupply.Cmp(asset.Cap) > 0 {
+		return nil, remainingGas, fmt.Errorf("%w: asset %s cap %s, requested total %s", ErrAssetCapExceeded, assetID, asset.Cap, newSupply)
+	}
+	asset.TotalSupply = newSupply
+	setAsset(stateDB, assetID, asset)
+
+	balanceKey := assetBalanceKey(assetID, to)
+	balance := stateDB.GetState(AssetRegistryAddress, balanceKey).Big()
+	stateDB.SetState(AssetRegistryAddress, balanceKey, common.BigToHash(new(big.Int).Add(balance, amount)))
+
+	return []byte{}, remainingGas, nil
+}
+
+// createBurnAsset burns [amount] of [assetID] from [from]'s balance.
+func createBurnAsset(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, MintGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	assetID, from, amount, err := unpackAssetAmountInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := getAllowListStatus(stateDB, AssetRegistryAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotBurnAsset, caller)
+	}
+
+	asset, err := getAsset(stateDB, assetID)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	balanceKey := assetBalanceKey(assetID, from)
+	balance := stateDB.GetState(AssetRegistryAddress, balanceKey).Big()
+	if balance.Cmp(amount) < 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %s has %s, requested %s", ErrInsufficientBalance, from, balance, amount)
+	}
+	stateDB.SetState(AssetRegistryAddress, balanceKey, common.BigToHash(new(big.Int).Sub(balance, amount)))
+
+	asset.TotalSupply = new(big.Int).Sub(asset.TotalSupply, amount)
+	setAsset(stateDB, assetID, asset)
+
+	return []byte{}, remainingGas, nil
+}
+
+// unpackAssetAmountInput decodes the (uint256 assetID, address target, uint256 amount) input
+// shared by mintAsset and burnAsset.
+func unpackAssetAmountInput(input []byte) (assetID *big.Int, target common.Address, amount *big.Int, err error) {
+	const wantLen = common.HashLength * 3
+	if len(input) != wantLen {
+		return nil, common.Address{}, nil, fmt.Errorf("invalid input length: %d", len(input))
+	}
+	assetID = new(big.Int).SetBytes(returnPackedElement(input, 0))
+	target = common.BytesToAddress(returnPackedElement(input, 1))
+	amount = new(big.Int).SetBytes(returnPackedElement(input, 2))
+	return assetID, target, amount, nil
+}
+
+// createGetAsset returns the name, symbol, decimals, cap, and total supply of [assetID].
+func createGetAsset(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, getAssetGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if len(input) != common.HashLength {
+		return nil, remainingGas, fmt.Errorf("invalid input length for getAsset: %d", len(input))
+	}
+	assetID := new(big.Int).SetBytes(input)
+
+	asset, err := getAsset(accessibleState.GetStateDB(), assetID)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	return packGetAssetOutput(asset), remainingGas, nil
+}
+
+// packGetAssetOutput ABI-encodes (string name, string symbol, uint8 decimals, uint256 cap,
+// uint256 totalSupply) for the getAsset return value.
+func packGetAssetOutput(asset Asset) []byte {
+	const headWords = 5
+	nameBytes := encodeDynamicBytes([]byte(asset.Name))
+	nameOffset := big.NewInt(headWords * int64(common.HashLength))
+	symbolOffset := new(big.Int).Add(nameOffset, big.NewInt(int64(len(nameBytes))))
+
+	out := make([]byte, 0, headWords*common.HashLength+len(nameBytes)+64)
+	out = append(out, nameOffset.FillBytes(make([]byte, 32))...)
+	out = append(out, symbolOffset.FillBytes(make([]byte, 32))...)
+	out = append(out, common.LeftPadBytes([]byte{asset.Decimals}, 32)...)
+	out = append(out, asset.Cap.FillBytes(make([]byte, 32))...)
+	out = append(out, asset.TotalSupply.FillBytes(make([]byte, 32))...)
+	out = append(out, nameBytes...)
+	out = append(out, encodeDynamicBytes([]byte(asset.Symbol))...)
+	return out
+}
+
+// encodeDynamicBytes ABI-encodes a dynamic `bytes`/`string` value: a 32-byte length word
+// followed by the data, right-padded to a multiple of 32 bytes.
+func encodeDynamicBytes(data []byte) []byte {
+	length := big.NewInt(int64(len(data)))
+	padded := make([]byte, (len(data)+31)/32*32)
+	copy(padded, data)
+	return append(length.FillBytes(make([]byte, 32)), padded...)
+}
+
+// createAssetRegistryPrecompile returns a StatefulPrecompiledContract with R/W control of an
+// allow list at [precompileAddr] and multi-asset registration, minting, burning, and reads.
+func createAssetRegistryPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	setAdmin := newStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AllowListAdmin))
+	setEnabled := newStatefulPrecompileFunction(setEnabledSignature, createAllowListRoleSetter(precompileAddr, AllowListEnabled))
+	setNone := newStatefulPrecompileFunction(setNoneSignature, createAllowListRoleSetter(precompileAddr, AllowListNoRole))
+	read := newStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
+
+	registerAsset := newStatefulPrecompileFunction(registerAssetSignature, createRegisterAsset)
+	mintAsset := newStatefulPrecompileFunction(mintAssetSignature, createMintAsset)
+	burnAsset := newStatefulPrecompileFunction(burnAssetSignature, createBurnAsset)
+	getAsset := newStatefulPrecompileFunction(getAssetSignature, createGetAsset)
+
+	// Construct the contract with no fallback function.
+	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read, registerAsset, mintAsset, burnAsset, getAsset})
+	return contract
+}
@@ -4,12 +4,15 @@
 package precompile
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
@@ -19,15 +22,64 @@ var (
 
 	mintSignature = CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
 
-	ErrCannotMint = errors.New("non-enabled cannot mint")
+	// mintWithAuthorizationSignature mirrors the EIP-7702 style delegated-authority pattern:
+	// delegator, delegate, maxAmount, deadline, recipient, nonce, v, r, s, to, amount.
+	mintWithAuthorizationSignature = CalculateFunctionSelector("mintWithAuthorization(address,address,uint256,uint256,address,uint256,uint8,bytes32,bytes32,address,uint256)")
+
+	ErrCannotMint           = errors.New("non-enabled cannot mint")
+	ErrInvalidAuthSignature = errors.New("invalid mint authorization signature")
+	ErrAuthorizationExpired = errors.New("mint authorization expired")
+	ErrUnauthorizedDelegate = errors.New("caller is not the authorized delegate")
+	ErrInvalidAuthNonce     = errors.New("invalid mint authorization nonce")
+	ErrBudgetExceeded       = errors.New("mint amount exceeds remaining authorization budget")
+	ErrRecipientNotAllowed  = errors.New("recipient not permitted by mint authorization")
+
+	// NativeMintedEventSignature is the topic0 hash emitted via stateDB.AddLog whenever native
+	// coins are minted (including genesis-seeded distributions), carrying (minter, to, amount)
+	// so indexers can subscribe without polling balances. The minter topic is the caller for
+	// mintNativeCoin, the delegator for mintWithAuthorization, and the zero address for
+	// genesis-seeded distributions. setAllowListRole calls made through
+	// this precompile's createAllowListRoleSetter emit their own RoleSet(address,address,uint8,
+	// uint8) log, defined alongside the shared allow-list helpers in allowlist.go.
+	NativeMintedEventSignature = crypto.Keccak256Hash([]byte("NativeMinted(address,address,uint256)"))
 
 	mintInputLen = common.HashLength + common.HashLength
+
+	// 11 fields packed for mintWithAuthorization, see mintWithAuthorizationSignature.
+	mintWithAuthorizationInputLen = common.HashLength * 11
+
+	// mintAuthorizationDomain separates mint authorization digests from other signed
+	// data that might reuse the same delegator key.
+	mintAuthorizationDomain = crypto.Keccak256Hash([]byte("ContractNativeMinterMintAuthorization"))
 )
 
+// mintAuthorizationGasCost is charged in addition to MintGasCost to cover signature
+// recovery when minting via a delegated authorization.
+const mintAuthorizationGasCost = 3000
+
+// MintAuthorization represents a bounded, time-limited mint budget that an
+// allow-listed delegator signs over to a delegate address, which can then submit
+// mintWithAuthorization calls on the delegator's behalf. This mirrors the
+// delegated code/action pattern introduced by EIP-7702, scoped to a capped
+// mint budget instead of full allow-list membership.
+type MintAuthorization struct {
+	Delegator common.Address
+	Delegate  common.Address
+	MaxAmount *big.Int
+	Deadline  *big.Int
+	Recipient common.Address // zero address permits minting to any recipient
+	Nonce     *big.Int
+}
+
 // ContractNativeMinterConfig wraps [AllowListConfig] and uses it to implement the StatefulPrecompileConfig
 // interface while adding in the contract deployer specific precompile address.
 type ContractNativeMinterConfig struct {
 	AllowListConfig
+
+	// InitialMintDistribution seeds native coin balances at activation, mirroring a
+	// Cosmos-style module InitGenesis so subgraphs can bootstrap from genesis without
+	// special-casing the chain's first mint.
+	InitialMintDistribution map[common.Address]*big.Int `json:"initialMintDistribution,omitempty"`
 }
 
 // Address returns the address of the native minter contract.
@@ -35,9 +87,31 @@ func (c *ContractNativeMinterConfig) Address() common.Address {
 	return ContractNativeMinterAddress
 }
 
-// Configure configures [state] with the desired admins based on [c].
+// Configure configures [state] with the desired admins based on [c], then seeds
+// [c.InitialMintDistribution] and emits a NativeMinted log per recipient so indexers can
+// bootstrap from genesis without special-casing it.
 func (c *ContractNativeMinterConfig) Configure(state StateDB) {
 	c.AllowListConfig.Configure(state, ContractNativeMinterAddress)
+
+	// Iterate recipients in a fixed order: Go's map iteration order is randomized, and log
+	// emission order is consensus-critical (it affects receipts and the block's bloom filter),
+	// so nondeterministic order here would let nodes disagree on the resulting block.
+	recipients := make([]common.Address, 0, len(c.InitialMintDistribution))
+	for to := range c.InitialMintDistribution {
+		recipients = append(recipients, to)
+	}
+	sort.Slice(recipients, func(i, j int) bool {
+		return bytes.Compare(recipients[i].Bytes(), recipients[j].Bytes()) < 0
+	})
+
+	for _, to := range recipients {
+		amount := c.InitialMintDistribution[to]
+		if !state.Exist(to) {
+			state.CreateAccount(to)
+		}
+		state.AddBalance(to, amount)
+		state.AddLog(ContractNativeMinterAddress, []common.Hash{NativeMintedEventSignature, common.Address{}.Hash(), to.Hash()}, amount.FillBytes(make([]byte, 32)), 0)
+	}
 }
 
 // Contract returns the singleton stateful precompiled contract to be used for the native minter.
@@ -79,6 +153,206 @@ func UnpackMintInput(input []byte) (common.Address, *big.Int, error) {
 	return to, assetAmount, nil
 }
 
+// PackMintWithAuthorizationInput packs [auth], the authorization signature ([v], [r], [s]),
+// and the mint recipient/amount into the appropriate arguments for the delegated mint operation.
+func PackMintWithAuthorizationInput(auth MintAuthorization, v uint8, r common.Hash, s common.Hash, to common.Address, amount *big.Int) ([]byte, error) {
+	fullLen := selectorLen + mintWithAuthorizationInputLen
+	packed := [][]byte{
+		mintWithAuthorizationSignature,
+		auth.Delegator.Hash().Bytes(),
+		auth.Delegate.Hash().Bytes(),
+		auth.MaxAmount.FillBytes(make([]byte, 32)),
+		auth.Deadline.FillBytes(make([]byte, 32)),
+		auth.Recipient.Hash().Bytes(),
+		auth.Nonce.FillBytes(make([]byte, 32)),
+		common.LeftPadBytes([]byte{v}, 32),
+		r.Bytes(),
+		s.Bytes(),
+		to.Hash().Bytes(),
+		amount.FillBytes(make([]byte, 32)),
+	}
+	return inputPackOrdered(packed, fullLen)
+}
+
+// UnpackMintWithAuthorizationInput attempts to unpack [input] into the authorization, its
+// signature, and the mint recipient/amount. assumes that [input] does not include the
+// selector (omits first 4 bytes in PackMintWithAuthorizationInput).
+func UnpackMintWithAuthorizationInput(input []byte) (MintAuthorization, uint8, common.Hash, common.Hash, common.Address, *big.Int, error) {
+	if len(input) != mintWithAuthorizationInputLen {
+		return MintAuthorization{}, 0, common.Hash{}, common.Hash{}, common.Address{}, nil, fmt.Errorf("invalid input length for mint authorization: %d", len(input))
+	}
+	auth := MintAuthorization{
+		Delegator: common.BytesToAddress(returnPackedElement(input, 0)),
+		Delegate:  common.BytesToAddress(returnPackedElement(input, 1)),
+		MaxAmount: new(big.Int).SetBytes(returnPackedElement(input, 2)),
+		Deadline:  new(big.Int).SetBytes(returnPackedElement(input, 3)),
+		Recipient: common.BytesToAddress(returnPackedElement(input, 4)),
+		Nonce:     new(big.Int).SetBytes(returnPackedElement(input, 5)),
+	}
+	v := returnPackedElement(input, 6)[31]
+	r := common.BytesToHash(returnPackedElement(input, 7))
+	s := common.BytesToHash(returnPackedElement(input, 8))
+	to := common.BytesToAddress(returnPackedElement(input, 9))
+	amount := new(big.Int).SetBytes(returnPackedElement(input, 10))
+	return auth, v, r, s, to, amount, nil
+}
+
+// mintAuthNonceKey returns the state key tracking the next valid authorization nonce
+// for [delegator]. An authorization can only be started while its nonce equals this value,
+// and starting it advances the value, so an authorization for an earlier nonce cannot be started.
+func mintAuthNonceKey(delegator common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("mintAuthNonce"), delegator.Bytes())
+}
+
+// mintAuthBudgetKey returns the state key tracking the remaining mint budget for the
+// authorization identified by [digest]. Keying by the full signed digest (which binds
+// MaxAmount and Deadline, not just the delegator/nonce pair) is deliberate: later calls
+// against an authorization skip the nonce check, which the first call has already advanced,
+// so keying on (delegator, nonce) alone would let any other authorization signed for the same
+// delegator and nonce spend the first one's leftover budget instead of its own signed MaxAmount.
+func mintAuthBudgetKey(digest common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("mintAuthBudget"), digest.Bytes())
+}
+
+// mintAuthBudgetInitializedKey returns the state key recording whether the authorization
+// identified by [digest] has already had its budget seeded from MaxAmount. Calls that find it
+// set read the remaining budget stored under [mintAuthBudgetKey] and skip the nonce check,
+// since the first call against the authorization has already advanced the nonce past it.
+func mintAuthBudgetInitializedKey(digest common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("mintAuthBudgetInitialized"), digest.Bytes())
+}
+
+// GetMintAuthorizationNonce returns the next valid authorization nonce for [delegator].
+func GetMintAuthorizationNonce(stateDB StateDB, delegator common.Address) *big.Int {
+	return stateDB.GetState(ContractNativeMinterAddress, mintAuthNonceKey(delegator)).Big()
+}
+
+// mintAuthorizationDigest computes the domain-separated hash that a delegator signs over
+// to authorize [delegate] to mint up to [auth.MaxAmount] on their behalf, optionally
+// restricted to [auth.Recipient]. Recipient is included so a delegate cannot redirect an
+// authorization to an address the delegator never signed off on.
+func mintAuthorizationDigest(chainID common.Hash, auth MintAuthorization) common.Hash {
+	return crypto.Keccak256Hash(
+		mintAuthorizationDomain.Bytes(),
+		chainID.Bytes(),
+		ContractNativeMinterAddress.Hash().Bytes(),
+		auth.Delegator.Hash().Bytes(),
+		auth.Delegate.Hash().Bytes(),
+		auth.MaxAmount.FillBytes(make([]byte, 32)),
+		auth.Deadline.FillBytes(make([]byte, 32)),
+		auth.Recipient.Hash().Bytes(),
+		auth.Nonce.FillBytes(make([]byte, 32)),
+	)
+}
+
+// verifyMintAuthorizationSignature recovers the signer of [digest] from [v], [r], [s] and
+// reports whether it matches [auth.Delegator].
+func verifyMintAuthorizationSignature(digest common.Hash, v uint8, r, s common.Hash, auth MintAuthorization) error {
+	if v >= 27 {
+		v -= 27
+	}
+	sig := make([]byte, 65)
+	copy(sig[0:32], r.Bytes())
+	copy(sig[32:64], s.Bytes())
+	sig[64] = v
+
+	pubKey, err := crypto.Ecrecover(digest.Bytes(), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAuthSignature, err)
+	}
+	recoveredPub, err := crypto.UnmarshalPubkey(pubKey)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAuthSignature, err)
+	}
+	if crypto.PubkeyToAddress(*recoveredPub) != auth.Delegator {
+		return ErrInvalidAuthSignature
+	}
+	return nil
+}
+
+// createMintWithAuthorization mints [amount] to [to] on behalf of [auth.Delegator], provided
+// [auth] carries a valid, unexpired signature from the delegator and the caller is the
+// authorized delegate. The first mint against [auth] advances the delegator's nonce past
+// [auth.Nonce], so a freshly-signed authorization can supersede it at any time; [auth] itself
+// remains spendable up to its own signed budget regardless of that advance.
+func createMintWithAuthorization(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, MintGasCost+mintAuthorizationGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	auth, v, r, s, to, amount, err := UnpackMintWithAuthorizationInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	if caller != auth.Delegate {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrUnauthorizedDelegate, caller)
+	}
+
+	blockTimestamp := accessibleState.GetBlockContext().Timestamp()
+	if blockTimestamp.Cmp(auth.Deadline) > 0 {
+		return nil, remainingGas, fmt.Errorf("%w: deadline %s", ErrAuthorizationExpired, auth.Deadline)
+	}
+
+	if auth.Recipient != (common.Address{}) && to != auth.Recipient {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrRecipientNotAllowed, to)
+	}
+
+	chainID := common.BytesToHash(accessibleState.GetSnowContext().ChainID[:])
+	digest := mintAuthorizationDigest(chainID, auth)
+	if err := verifyMintAuthorizationSignature(digest, v, r, s, auth); err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only an allow-listed delegator may grant a mint authorization in the first place.
+	delegatorStatus := getAllowListStatus(stateDB, ContractNativeMinterAddress, auth.Delegator)
+	if !delegatorStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, auth.Delegator)
+	}
+
+	budgetKey := mintAuthBudgetKey(digest)
+	initializedKey := mintAuthBudgetInitializedKey(digest)
+	var remainingBudget *big.Int
+	if stateDB.GetState(ContractNativeMinterAddress, initializedKey) == (common.Hash{}) {
+		// First use of this exact authorization: it must match the delegator's current nonce.
+		// Advance the nonce right here, rather than waiting for the budget to be fully spent,
+		// so a partially-spent authorization can never block the delegator from superseding it
+		// with a freshly-signed one at the next nonce. The authorization itself stays spendable
+		// for its remaining budget regardless, since every later call against this same digest
+		// takes the [initializedKey] branch below and never re-checks the (now-advanced) nonce.
+		currentNonce := GetMintAuthorizationNonce(stateDB, auth.Delegator)
+		if auth.Nonce.Cmp(currentNonce) != 0 {
+			return nil, remainingGas, fmt.Errorf("%w: expected %s, got %s", ErrInvalidAuthNonce, currentNonce, auth.Nonce)
+		}
+		remainingBudget = new(big.Int).Set(auth.MaxAmount)
+		stateDB.SetState(ContractNativeMinterAddress, initializedKey, common.BigToHash(common.Big1))
+		stateDB.SetState(ContractNativeMinterAddress, mintAuthNonceKey(auth.Delegator), common.BigToHash(new(big.Int).Add(auth.Nonce, common.Big1)))
+	} else {
+		// A prior partial mint against this exact authorization has already seeded the budget
+		// and advanced the nonce past it; spend continues to be tracked here regardless.
+		remainingBudget = stateDB.GetState(ContractNativeMinterAddress, budgetKey).Big()
+	}
+
+	if amount.Cmp(remainingBudget) > 0 {
+		return nil, remainingGas, fmt.Errorf("%w: remaining %s, requested %s", ErrBudgetExceeded, remainingBudget, amount)
+	}
+	remainingBudget = new(big.Int).Sub(remainingBudget, amount)
+	stateDB.SetState(ContractNativeMinterAddress, budgetKey, common.BigToHash(remainingBudget))
+
+	if !stateDB.Exist(to) {
+		stateDB.CreateAccount(to)
+	}
+	stateDB.AddBalance(to, amount)
+	stateDB.AddLog(ContractNativeMinterAddress, []common.Hash{NativeMintedEventSignature, auth.Delegator.Hash(), to.Hash()}, amount.FillBytes(make([]byte, 32)), accessibleState.GetBlockContext().Number().Uint64())
+
+	return []byte{}, remainingGas, nil
+}
+
 // createMintNativeCoin checks if the caller is permissioned for minting operation.
 // The execution function parses the [input] into native coin amount and receiver address.
 func createMintNativeCoin(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -108,6 +382,7 @@ func createMintNativeCoin(accessibleState PrecompileAccessibleState, caller comm
 	}
 
 	stateDB.AddBalance(to, amount)
+	stateDB.AddLog(ContractNativeMinterAddress, []common.Hash{NativeMintedEventSignature, caller.Hash(), to.Hash()}, amount.FillBytes(make([]byte, 32)), accessibleState.GetBlockContext().Number().Uint64())
 	// Return an empty output and the remaining gas
 	return []byte{}, remainingGas, nil
 }
@@ -120,8 +395,9 @@ func createNativeMinterPrecompile(precompileAddr common.Address) StatefulPrecomp
 	read := newStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
 
 	mint := newStatefulPrecompileFunction(mintSignature, createMintNativeCoin)
+	mintWithAuthorization := newStatefulPrecompileFunction(mintWithAuthorizationSignature, createMintWithAuthorization)
 
 	// Construct the contract with no fallback function.
-	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read, mint})
+	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read, mint, mintWithAuthorization})
 	return contract
 }
@@ -4,89 +4,210 @@
 package precompile
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"math/big"
 
-	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
 	_ StatefulPrecompileConfig = &ContractXChainECRecoverConfig{}
-	// Singleton StatefulPrecompiledContract for minting native assets by permissioned callers.
+	// Singleton StatefulPrecompiledContract for recovering Avalanche X-Chain/P-Chain addresses.
 	ContractXChainECRecoverPrecompile StatefulPrecompiledContract = createXChainECRecoverPrecompile(ContractXchainECRecoverAddress)
 
-	xChainECRecoverSignature = CalculateFunctionSelector("xChainECRecover(string)") // address, amount
-	xChainECRecoverReadSignature = CalculateFunctionSelector("getXChainECRecover(string)")
+	xChainECRecoverSignature           = CalculateFunctionSelector("xChainECRecover(bytes,bytes)") // message, signature
+	xChainAddressToEthAddressSignature = CalculateFunctionSelector("xChainAddressToEthAddress(bytes)")
+
+	// avalancheSignedMessagePrefix matches the prefix avalanchego wallets use when signing an
+	// arbitrary message, so a signature produced off-chain for an X-Chain/P-Chain key verifies
+	// identically here.
+	avalancheSignedMessagePrefix = []byte("\x1AAvalanche Signed Message:\n")
+
+	ErrInvalidSignatureLen = errors.New("invalid signature length, expected 65 bytes")
+	ErrMalleableSignature  = errors.New("signature S value is malleable (not in lower half order)")
+	ErrInvalidXChainAddr   = errors.New("address is not a valid X-Chain or P-Chain address")
+)
+
+// xChainECRecoverGasCost approximates ECRECOVER's base cost plus a per-byte charge for hashing
+// the signed message.
+const (
+	xChainECRecoverGasCost           = 3000
+	xChainECRecoverPerByteGasCost    = 6
+	xChainAddressToEthAddressGasCost = 1500
 )
 
-// ContractXChainECRecoverConfig wraps [AllowListConfig] and uses it to implement the StatefulPrecompileConfig
-// interface while adding in the contract deployer specific precompile address.
+// ContractXChainECRecoverConfig wraps a BlockTimestamp to gate activation of the precompile.
 type ContractXChainECRecoverConfig struct {
 	BlockTimestamp *big.Int `json:"blockTimestamp"`
 }
 
-// Address returns the address of the native minter contract.
+// Address returns the address of the X-Chain ECRecover contract.
 func (c *ContractXChainECRecoverConfig) Address() common.Address {
 	return ContractXchainECRecoverAddress
 }
 
-// Contract returns the singleton stateful precompiled contract to be used for the native minter.
+// Contract returns the singleton stateful precompiled contract used for X-Chain ECRecover.
 func (c *ContractXChainECRecoverConfig) Contract() StatefulPrecompiledContract {
 	return ContractXChainECRecoverPrecompile
 }
 
-// Configure configures [state] with the desired admins based on [c].
-func (c *ContractXChainECRecoverConfig) Configure(state StateDB) {
-	
-}
+// Configure is a no-op: this precompile holds no state of its own.
+func (c *ContractXChainECRecoverConfig) Configure(state StateDB) {}
 
 func (c *ContractXChainECRecoverConfig) Timestamp() *big.Int { return c.BlockTimestamp }
 
-// createXChainECRecover checks if the caller is permissioned for minting operation.
-// The execution function parses the [input] into native coin amount and receiver address.
+// decodeABIBytes decodes the dynamic `bytes` value located at [offset] within [input].
+// Bounds are checked via subtraction against the known-good len(input) rather than by adding
+// to the attacker-controlled [offset]/length, since those additions can wrap a uint64 and
+// defeat the check, leading to an out-of-bounds slice panic.
+func decodeABIBytes(input []byte, offset uint64) ([]byte, error) {
+	inputLen := uint64(len(input))
+	if offset > inputLen || common.HashLength > inputLen-offset {
+		return nil, fmt.Errorf("invalid bytes offset %d for input of length %d", offset, len(input))
+	}
+	length := new(big.Int).SetBytes(input[offset : offset+common.HashLength]).Uint64()
+	start := offset + common.HashLength
+	if length > inputLen-start {
+		return nil, fmt.Errorf("invalid bytes length %d at offset %d", length, offset)
+	}
+	return input[start : start+length], nil
+}
+
+// decodeMessageAndSignature decodes an ABI-encoded (bytes message, bytes signature) payload.
+func decodeMessageAndSignature(input []byte) (message []byte, signature []byte, err error) {
+	if len(input) < common.HashLength*2 {
+		return nil, nil, fmt.Errorf("input too short for (bytes,bytes): %d", len(input))
+	}
+	messageOffset := new(big.Int).SetBytes(input[0:common.HashLength]).Uint64()
+	signatureOffset := new(big.Int).SetBytes(input[common.HashLength : 2*common.HashLength]).Uint64()
+
+	message, err = decodeABIBytes(input, messageOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err = decodeABIBytes(input, signatureOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, signature, nil
+}
+
+// avalancheMessageDigest hashes [message] the same way avalanchego's wallet signer does:
+// a domain-separating prefix, the varint-encoded message length, then the message itself.
+func avalancheMessageDigest(message []byte) [32]byte {
+	lengthPrefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lengthPrefix, uint64(len(message)))
+
+	prefixed := make([]byte, 0, len(avalancheSignedMessagePrefix)+n+len(message))
+	prefixed = append(prefixed, avalancheSignedMessagePrefix...)
+	prefixed = append(prefixed, lengthPrefix[:n]...)
+	prefixed = append(prefixed, message...)
+	return sha256.Sum256(prefixed)
+}
+
+// recoverShortAddress recovers the 20-byte Avalanche short address (RIPEMD160(SHA256(pubkey)))
+// of the signer of [digest] from the 65-byte [R||S||V] [signature], rejecting malleable
+// high-S signatures.
+func recoverShortAddress(digest [32]byte, signature []byte) ([20]byte, error) {
+	var shortAddr [20]byte
+	if len(signature) != 65 {
+		return shortAddr, ErrInvalidSignatureLen
+	}
+
+	r := new(big.Int).SetBytes(signature[0:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+
+	secpHalfN := new(big.Int).Rsh(crypto.S256().Params().N, 1)
+	if s.Cmp(secpHalfN) > 0 {
+		return shortAddr, ErrMalleableSignature
+	}
+
+	sig := make([]byte, 65)
+	r.FillBytes(sig[0:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = v
+
+	pubKeyBytes, err := crypto.Ecrecover(digest[:], sig)
+	if err != nil {
+		return shortAddr, fmt.Errorf("ecrecover failed: %w", err)
+	}
+	pubKey, err := crypto.UnmarshalPubkey(pubKeyBytes)
+	if err != nil {
+		return shortAddr, fmt.Errorf("invalid recovered public key: %w", err)
+	}
+
+	copy(shortAddr[:], hashing.ComputeHash160(crypto.CompressPubkey(pubKey)))
+	return shortAddr, nil
+}
+
+// createXChainECRecover recovers the Avalanche short address that signed [message] and
+// returns it as a 20-byte address left-padded to a 32-byte word, allowing Solidity contracts
+// to prove ownership of an X-Chain or P-Chain address from inside the C-Chain/subnet EVM.
 func createXChainECRecover(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	log.Info("Reached 1 1");
-	if remainingGas, err = deductGas(suppliedGas, MintGasCost); err != nil {
+	message, signature, err := decodeMessageAndSignature(input)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	gasCost := uint64(xChainECRecoverGasCost) + uint64(len(message))*xChainECRecoverPerByteGasCost
+	if remainingGas, err = deductGas(suppliedGas, gasCost); err != nil {
 		return nil, 0, err
 	}
 
-	if readOnly {
-		return nil, remainingGas, vmerrs.ErrWriteProtection
+	digest := avalancheMessageDigest(message)
+	shortAddr, err := recoverShortAddress(digest, signature)
+	if err != nil {
+		return nil, remainingGas, err
 	}
-	
-	log.Info("Reached 1 2");
-	log.Info(string(input[:]));
-	// Return an empty output and the remaining gas
-	out := []byte(string(input[:]))
-	return out, remainingGas, nil
+
+	return common.BytesToAddress(shortAddr[:]).Hash().Bytes(), remainingGas, nil
 }
 
-// createReadAllowList returns an execution function that reads the allow list for the given [precompileAddr].
-// The execution function parses the input into a single address and returns the 32 byte hash that specifies the
-// designated role of that address
-func getXChainECRecover(precompileAddr common.Address) RunStatefulPrecompileFunc {
-	log.Info("Reached 2 1");
-	return func(evm PrecompileAccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-		if remainingGas, err = deductGas(suppliedGas, ReadAllowListGasCost); err != nil {
-			return nil, 0, err
-		}
-		log.Info("Reached 2 2");
-		log.Info(string(input[:]));
-	
-
-		out := []byte(string(input[:]))
-		return out, remainingGas, nil
+// createXChainAddressToEthAddress Bech32-decodes an `X-`/`P-` formatted address string and
+// returns its 20-byte hash, so callers can cross-check it against createXChainECRecover's
+// output.
+func createXChainAddressToEthAddress(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, xChainAddressToEthAddressGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if len(input) < common.HashLength {
+		return nil, remainingGas, fmt.Errorf("input too short for (bytes): %d", len(input))
 	}
+	offset := new(big.Int).SetBytes(input[0:common.HashLength]).Uint64()
+	addrBytes, err := decodeABIBytes(input, offset)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	chainIDAlias, _, decoded, err := address.Parse(string(addrBytes))
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrInvalidXChainAddr, err)
+	}
+	if chainIDAlias != "X" && chainIDAlias != "P" {
+		return nil, remainingGas, fmt.Errorf("%w: unexpected chain alias %q", ErrInvalidXChainAddr, chainIDAlias)
+	}
+
+	return common.BytesToAddress(decoded).Hash().Bytes(), remainingGas, nil
 }
 
-// createXChainECRecoverPrecompile returns a StatefulPrecompiledContract with R/W control of an allow list at [precompileAddr] and a native coin minter.
+// createXChainECRecoverPrecompile returns a StatefulPrecompiledContract exposing
+// xChainECRecover and xChainAddressToEthAddress at [precompileAddr].
 func createXChainECRecoverPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
-	log.Info("Reached 1");
 	xChainECRecover := newStatefulPrecompileFunction(xChainECRecoverSignature, createXChainECRecover)
-	_getXChainECRecover := newStatefulPrecompileFunction(xChainECRecoverReadSignature, getXChainECRecover(precompileAddr))
+	xChainAddressToEthAddress := newStatefulPrecompileFunction(xChainAddressToEthAddressSignature, createXChainAddressToEthAddress)
 
 	// Construct the contract with no fallback function.
-	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{xChainECRecover,_getXChainECRecover})
+	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{xChainECRecover, xChainAddressToEthAddress})
 	return contract
 }
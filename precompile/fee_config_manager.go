@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -25,6 +26,10 @@ const (
 
 	minKey = gasLimitKey
 	maxKey = blockGasCostStepKey
+
+	// lastChangedAtKey stores the block number of the most recent setFeeConfig call,
+	// reserved just past the packed FeeConfig fields.
+	lastChangedAtKey = maxKey + 1
 )
 
 // TODO: edit comments
@@ -34,9 +39,13 @@ var (
 	// Singleton StatefulPrecompiledContract for minting native assets by permissioned callers.
 	FeeConfigManagerPrecompile StatefulPrecompiledContract = createFeeConfigManagerPrecompile(FeeConfigManagerAddress)
 
-	setFeeConfigSignature = CalculateFunctionSelector("setFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
-	// TODO: do we need that?
-	// getFeeConfigSignature = CalculateFunctionSelector("getFeeConfig()")
+	setFeeConfigSignature        = CalculateFunctionSelector("setFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
+	getFeeConfigSignature        = CalculateFunctionSelector("getFeeConfig()")
+	getFeeConfigLastChangedAtSig = CalculateFunctionSelector("getFeeConfigLastChangedAt()")
+
+	// FeeConfigChangedEventSignature is the topic0 hash emitted via stateDB.AddLog whenever
+	// setFeeConfig succeeds, carrying the old and new FeeConfig as ABI-encoded data.
+	FeeConfigChangedEventSignature = crypto.Keccak256Hash([]byte("FeeConfigChanged(address,FeeConfig,FeeConfig)"))
 
 	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
 
@@ -63,6 +72,11 @@ type FeeConfig struct {
 // interface while adding in the contract deployer specific precompile address.
 type FeeConfigManagerConfig struct {
 	AllowListConfig
+
+	// InitialFeeConfig seeds the active FeeConfig at activation, mirroring a Cosmos-style
+	// module InitGenesis so subgraphs can bootstrap from genesis without special-casing the
+	// chain's first setFeeConfig call.
+	InitialFeeConfig *FeeConfig `json:"initialFeeConfig,omitempty"`
 }
 
 // Address returns the address of the fee config manager contract.
@@ -70,9 +84,18 @@ func (c *FeeConfigManagerConfig) Address() common.Address {
 	return FeeConfigManagerAddress
 }
 
-// Configure configures [state] with the desired admins based on [c].
+// Configure configures [state] with the desired admins based on [c], then seeds
+// [c.InitialFeeConfig] and emits a FeeConfigChanged log so indexers can bootstrap from
+// genesis without special-casing it.
 func (c *FeeConfigManagerConfig) Configure(state StateDB) {
 	c.AllowListConfig.Configure(state, FeeConfigManagerAddress)
+
+	if c.InitialFeeConfig != nil {
+		oldFeeConfig, _ := GetFeeConfig(state)
+		setFeeConfig(state, *c.InitialFeeConfig, common.Big0)
+		logData := append(packFeeConfig(oldFeeConfig), packFeeConfig(*c.InitialFeeConfig)...)
+		state.AddLog(FeeConfigManagerAddress, []common.Hash{FeeConfigChangedEventSignature, common.Address{}.Hash()}, logData, 0)
+	}
 }
 
 // Contract returns the singleton stateful precompiled contract to be used for the native minter.
@@ -129,7 +152,19 @@ func UnpackFeeConfigInput(input []byte) (FeeConfig, error) {
 
 func GetFeeConfig(stateDB StateDB) (FeeConfig, error) {
 	if !stateDB.Exist(FeeConfigManagerAddress) {
-		return FeeConfig{}, nil
+		// No fee config has ever been set: synthesize the zero FeeConfig rather than a
+		// struct of nil *big.Int fields, since callers (e.g. Configure, createSetFeeConfig) pass
+		// the result to packFeeConfig, and nil.FillBytes panics.
+		return FeeConfig{
+			GasLimit:                 common.Big0,
+			TargetBlockRate:          common.Big0,
+			MinBaseFee:               common.Big0,
+			TargetGas:                common.Big0,
+			BaseFeeChangeDenominator: common.Big0,
+			MinBlockGasCost:          common.Big0,
+			MaxBlockGasCost:          common.Big0,
+			BlockGasCostStep:         common.Big0,
+		}, nil
 	}
 	feeConfig := FeeConfig{}
 	for i := minKey; i <= maxKey; i++ {
@@ -158,7 +193,29 @@ func GetFeeConfig(stateDB StateDB) (FeeConfig, error) {
 	return feeConfig, nil
 }
 
-func setFeeConfig(stateDB StateDB, feeConfig FeeConfig) error {
+// packFeeConfig encodes [feeConfig] as the 8 packed 32-byte words shared by the
+// setFeeConfig input and the getFeeConfig return value.
+func packFeeConfig(feeConfig FeeConfig) []byte {
+	out := make([]byte, 0, feeConfigInputLen)
+	out = append(out, feeConfig.GasLimit.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.TargetBlockRate.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.MinBaseFee.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.TargetGas.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.BaseFeeChangeDenominator.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.MinBlockGasCost.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.MaxBlockGasCost.FillBytes(make([]byte, 32))...)
+	out = append(out, feeConfig.BlockGasCostStep.FillBytes(make([]byte, 32))...)
+	return out
+}
+
+// GetFeeConfigLastChangedAt returns the block number of the most recent setFeeConfig call,
+// or zero if the fee config has never been changed.
+func GetFeeConfigLastChangedAt(stateDB StateDB) *big.Int {
+	return stateDB.GetState(FeeConfigManagerAddress, common.Hash{byte(lastChangedAtKey)}).Big()
+}
+
+func setFeeConfig(stateDB StateDB, feeConfig FeeConfig, blockNumber *big.Int) error {
+	stateDB.SetState(FeeConfigManagerAddress, common.Hash{byte(lastChangedAtKey)}, common.BigToHash(blockNumber))
 	for i := minKey; i <= maxKey; i++ {
 		var hashInput common.Hash
 		switch i {
@@ -189,7 +246,8 @@ func setFeeConfig(stateDB StateDB, feeConfig FeeConfig) error {
 // createMintNativeCoin checks if the caller is permissioned for minting operation.
 // The execution function parses the [input] into native coin amount and receiver address.
 func createSetFeeConfig(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	if remainingGas, err = deductGas(suppliedGas, SetFeeConfigGasCost); err != nil {
+	// (maxKey-minKey+1) packed FeeConfig fields plus the lastChangedAtKey slot this chunk added.
+	if remainingGas, err = deductGas(suppliedGas, writeGasCostPerSlot*(maxKey-minKey+2)); err != nil {
 		return nil, 0, err
 	}
 
@@ -209,12 +267,47 @@ func createSetFeeConfig(accessibleState PrecompileAccessibleState, caller common
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
 	}
 
-	setFeeConfig(accessibleState.GetStateDB(), feeConfig)
+	oldFeeConfig, err := GetFeeConfig(stateDB)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	blockNumber := accessibleState.GetBlockContext().Number()
+	if err := setFeeConfig(stateDB, feeConfig, blockNumber); err != nil {
+		return nil, remainingGas, err
+	}
+
+	logData := append(packFeeConfig(oldFeeConfig), packFeeConfig(feeConfig)...)
+	stateDB.AddLog(FeeConfigManagerAddress, []common.Hash{FeeConfigChangedEventSignature, caller.Hash()}, logData, blockNumber.Uint64())
 
 	// Return an empty output and the remaining gas
 	return []byte{}, remainingGas, nil
 }
 
+// createGetFeeConfig returns the currently active FeeConfig as 8 packed 32-byte words.
+func createGetFeeConfig(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, ReadAllowListGasCost*(maxKey-minKey+1)); err != nil {
+		return nil, 0, err
+	}
+
+	feeConfig, err := GetFeeConfig(accessibleState.GetStateDB())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	return packFeeConfig(feeConfig), remainingGas, nil
+}
+
+// createGetFeeConfigLastChangedAt returns the block number of the most recent setFeeConfig call.
+func createGetFeeConfigLastChangedAt(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, ReadAllowListGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	lastChangedAt := GetFeeConfigLastChangedAt(accessibleState.GetStateDB())
+	return common.BigToHash(lastChangedAt).Bytes(), remainingGas, nil
+}
+
 // createNativeMinterPrecompile returns a StatefulPrecompiledContract with R/W control of an allow list at [precompileAddr] and a native coin minter.
 func createFeeConfigManagerPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
 	setAdmin := newStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AllowListAdmin))
@@ -223,8 +316,10 @@ func createFeeConfigManagerPrecompile(precompileAddr common.Address) StatefulPre
 	read := newStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
 
 	setFeeConfig := newStatefulPrecompileFunction(setFeeConfigSignature, createSetFeeConfig)
+	getFeeConfig := newStatefulPrecompileFunction(getFeeConfigSignature, createGetFeeConfig)
+	getFeeConfigLastChangedAt := newStatefulPrecompileFunction(getFeeConfigLastChangedAtSig, createGetFeeConfigLastChangedAt)
 
 	// Construct the contract with no fallback function.
-	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read, setFeeConfig})
+	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read, setFeeConfig, getFeeConfig, getFeeConfigLastChangedAt})
 	return contract
 }
@@ -0,0 +1,84 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ StatefulPrecompileConfig = &TxAllowListConfig{}
+	// Singleton StatefulPrecompiledContract for gating which addresses may submit transactions.
+	TxAllowListPrecompile StatefulPrecompiledContract = createTxAllowListPrecompile(TxAllowListAddress)
+)
+
+// TxAllowListConfig wraps [AllowListConfig] and uses it to implement the StatefulPrecompileConfig
+// interface while adding the tx allow list specific precompile address. Unlike the other
+// allow-list precompiles, this one gates transaction submission rather than a single contract
+// call, so its activation is timestamp-gated (mirroring MinBaseFee-style network upgrades)
+// letting existing subnets enable it at a future block without invalidating history; that gate
+// is the [AllowListConfig.Timestamp] already embedded below, not a second one of our own.
+type TxAllowListConfig struct {
+	AllowListConfig
+}
+
+// Address returns the address of the tx allow list contract.
+func (c *TxAllowListConfig) Address() common.Address {
+	return TxAllowListAddress
+}
+
+// Configure configures [state] with the desired admins based on [c].
+func (c *TxAllowListConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, TxAllowListAddress)
+}
+
+// Contract returns the singleton stateful precompiled contract to be used for the tx allow list.
+func (c *TxAllowListConfig) Contract() StatefulPrecompiledContract {
+	return TxAllowListPrecompile
+}
+
+// GetTxAllowListStatus returns the role of [address] for the tx allow list.
+func GetTxAllowListStatus(stateDB StateDB, address common.Address) AllowListRole {
+	return getAllowListStatus(stateDB, TxAllowListAddress, address)
+}
+
+// SetTxAllowListStatus sets the permissions of [address] to [role] for the tx allow list.
+// assumes [role] has already been verified as valid.
+func SetTxAllowListStatus(stateDB StateDB, address common.Address, role AllowListRole) {
+	setAllowListRole(stateDB, TxAllowListAddress, address, role)
+}
+
+// IsActivated reports whether [c] has been enabled as of [blockTimestamp]. A nil [c] (the tx
+// allow list absent from ChainConfig) or a nil/future Timestamp means not yet active.
+func (c *TxAllowListConfig) IsActivated(blockTimestamp *big.Int) bool {
+	if c == nil || c.Timestamp() == nil || blockTimestamp == nil {
+		return false
+	}
+	return blockTimestamp.Cmp(c.Timestamp()) >= 0
+}
+
+// IsAllowedTxSender reports whether [sender] may submit a transaction, per the tx allow list's
+// current state in [stateDB]. This is the call the txpool (on transaction admission) and the
+// block-verification path (when validating a block's transactions) must make with the recovered
+// sender before accepting a transaction; those call sites live outside this chunk's tree and are
+// expected to gate the call on [TxAllowListConfig.IsActivated] themselves before invoking this,
+// the same way they already gate on a precompile's presence in ChainConfig for other upgrades.
+func IsAllowedTxSender(stateDB StateDB, sender common.Address) bool {
+	return getAllowListStatus(stateDB, TxAllowListAddress, sender) != AllowListNoRole
+}
+
+// createTxAllowListPrecompile returns a StatefulPrecompiledContract with R/W control of an
+// allow list at [precompileAddr] gating who may submit transactions.
+func createTxAllowListPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	setAdmin := newStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AllowListAdmin))
+	setEnabled := newStatefulPrecompileFunction(setEnabledSignature, createAllowListRoleSetter(precompileAddr, AllowListEnabled))
+	setNone := newStatefulPrecompileFunction(setNoneSignature, createAllowListRoleSetter(precompileAddr, AllowListNoRole))
+	read := newStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
+
+	// Construct the contract with no fallback function.
+	contract := newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{setAdmin, setEnabled, setNone, read})
+	return contract
+}